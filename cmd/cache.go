@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/7csc/domain-checker/internal/ipranges"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or manage the cached cloud IP-range files",
+}
+
+var cacheRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Force a re-fetch of the AWS and GCP IP-range files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, p := range []ipranges.Provider{ipranges.AWS, ipranges.GCP} {
+			if _, err := rangesCache.Refresh(cmd.Context(), p); err != nil {
+				return fmt.Errorf("refreshing %s ranges: %w", p, err)
+			}
+			fmt.Printf("refreshed %s ranges\n", p)
+		}
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the on-disk IP-range cache",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rangesCache.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("cache cleared")
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheRefreshCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
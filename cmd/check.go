@@ -1,29 +1,93 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
+
+	"github.com/7csc/domain-checker/internal/ipranges"
+	"github.com/7csc/domain-checker/internal/output"
+	"github.com/7csc/domain-checker/internal/resolver"
 )
 
+const ipRangesTTL = 24 * time.Hour
+
+var rangesCache *ipranges.Cache
+
+func init() {
+	dir, err := ipranges.DefaultCacheDir()
+	if err != nil {
+		dir = ".doch-cache"
+	}
+	rangesCache = ipranges.NewCache(dir, ipRangesTTL)
+}
+
 var (
-	filePath string
-	verbose  bool
+	filePath      string
+	verbose       bool
+	concurrency   int
+	outputFormat  string
+	outputFile    string
+	tlsWarnDays   int
+	resolverSpecs []string
+
+	resolvers []resolver.Resolver
 )
 
 type Domain struct {
-	Name  string         `toml:"name"`
-	Ports map[string]int `toml:"ports"`
+	Name            string            `toml:"name"`
+	Ports           map[string]int    `toml:"ports"`
+	Resolver        string            `toml:"resolver"`
+	Path            string            `toml:"path"`
+	ExpectStatus    []int             `toml:"expect_status"`
+	Headers         map[string]string `toml:"headers"`
+	FollowRedirects *bool             `toml:"follow_redirects"`
+	Skip            []string          `toml:"skip"`
+	Timeout         string            `toml:"timeout"`
+}
+
+// skips reports whether the domain opts out of the named check
+// ("smtp" or "cloud").
+func (d Domain) skips(check string) bool {
+	for _, s := range d.Skip {
+		if s == check {
+			return true
+		}
+	}
+	return false
+}
+
+// expectedStatus reports whether status counts as "up" for this
+// domain: any code in ExpectStatus if given, otherwise anything below
+// 400.
+func (d Domain) expectedStatus(status int) bool {
+	if len(d.ExpectStatus) == 0 {
+		return status < 400
+	}
+	for _, s := range d.ExpectStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// path returns the request path to probe, defaulting to "/".
+func (d Domain) path() string {
+	if d.Path == "" {
+		return "/"
+	}
+	return d.Path
 }
 
 type Config struct {
@@ -38,7 +102,12 @@ var checkCmd = &cobra.Command{
 
 func init() {
 	checkCmd.Flags().StringVarP(&filePath, "file", "f", "domains.toml", "Path to TOML file")
-	checkCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	checkCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output (alias for --log-level=debug)")
+	checkCmd.Flags().IntVarP(&concurrency, "concurrency", "c", 10, "Number of domains to check in parallel")
+	checkCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, yaml, csv, prom")
+	checkCmd.Flags().StringVar(&outputFile, "output-file", "", "Write output to this file instead of stdout")
+	checkCmd.Flags().IntVar(&tlsWarnDays, "tls-warn-days", 0, "Exit non-zero if any domain's certificate expires within this many days (0 disables the check)")
+	checkCmd.Flags().StringArrayVar(&resolverSpecs, "resolver", nil, "DNS resolver to use (repeatable): udp://host:port, tls://host:port, https://host/path (default: system resolver)")
 }
 
 func runCheck(cmd *cobra.Command, args []string) {
@@ -48,8 +117,110 @@ func runCheck(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	results := checkDomains(config.Domains)
-	displayResults(config.Domains, results)
+	outputter, err := output.New(outputFormat)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	resolvers, err = parseResolvers(resolverSpecs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	results := checkDomains(ctx, config.Domains)
+
+	dest := os.Stdout
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			fmt.Printf("Failed to open output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		dest = f
+	}
+
+	if err := outputter.Write(dest, results); err != nil {
+		fmt.Printf("Failed to write output: %v\n", err)
+		os.Exit(1)
+	}
+
+	if tlsWarnDays > 0 && anyCertBelowThreshold(results, tlsWarnDays) {
+		os.Exit(1)
+	}
+}
+
+// parseResolvers turns --resolver flag values into Resolvers. With
+// none given, doch falls back to whatever the OS has configured.
+func parseResolvers(specs []string) ([]resolver.Resolver, error) {
+	if len(specs) == 0 {
+		return []resolver.Resolver{resolver.System{}}, nil
+	}
+
+	parsed := make([]resolver.Resolver, 0, len(specs))
+	for _, spec := range specs {
+		r, err := resolver.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+// resolverFor picks the resolver a domain should be looked up with: its
+// own TOML override if it has one, otherwise the first configured
+// --resolver (or the system resolver).
+func resolverFor(domain Domain) resolver.Resolver {
+	if domain.Resolver == "" {
+		return resolvers[0]
+	}
+
+	r, err := resolver.Parse(domain.Resolver)
+	if err != nil {
+		logger.Warn().Str("domain", domain.Name).Str("resolver", domain.Resolver).Err(err).Msg("invalid per-domain resolver, falling back to default")
+		return resolvers[0]
+	}
+	return r
+}
+
+// logResolverDiff queries every additional configured resolver (beyond
+// the one actually used) purely so --log-level=debug users can diff
+// what different resolvers see for the same domain.
+func logResolverDiff(ctx context.Context, domain string, primary resolver.Resolver) {
+	if len(resolvers) < 2 {
+		return
+	}
+
+	for _, r := range resolvers {
+		if r == primary {
+			continue
+		}
+		ips, err := r.LookupA(ctx, domain)
+		if err != nil {
+			logger.Debug().Str("domain", domain).Str("resolver", r.String()).Err(err).Msg("resolver diff")
+			continue
+		}
+		logger.Debug().Str("domain", domain).Str("resolver", r.String()).Interface("ips", ips).Msg("resolver diff")
+	}
+}
+
+func anyCertBelowThreshold(results []output.Result, warnDays int) bool {
+	threshold := time.Duration(warnDays) * 24 * time.Hour
+	for _, result := range results {
+		if result.TLS == nil {
+			continue
+		}
+		if !result.TLS.Valid() || time.Until(result.TLS.NotAfter) < threshold {
+			return true
+		}
+	}
+	return false
 }
 
 func loadConfig(filename string) (*Config, error) {
@@ -64,145 +235,325 @@ func loadConfig(filename string) (*Config, error) {
 	return &config, nil
 }
 
-func checkDomains(domains []Domain) []map[string]string {
-	client := &http.Client{Timeout: 10 * time.Second}
+// domainState tracks where a single domain is in the pipeline so the
+// progress renderer can draw one line per domain without overlapping
+// spinners clobbering each other.
+type domainState int
 
-	var results []map[string]string
+const (
+	statePending domainState = iota
+	stateInFlight
+	stateDone
+)
 
-	for _, domain := range domains {
-		done := make(chan struct{})
-		go showLoading(domain.Name, done)
+type progress struct {
+	mu     sync.Mutex
+	order  []string
+	states map[string]domainState
+}
 
-		ipAddress, finalHost := getIpAddress(domain.Name)
+func newProgress(domains []Domain) *progress {
+	p := &progress{states: make(map[string]domainState, len(domains))}
+	for _, d := range domains {
+		p.order = append(p.order, d.Name)
+		p.states[d.Name] = statePending
+	}
+	return p
+}
 
-		status := "deactive"
-		cloud := "unknown"
-		service := "unknown"
+func (p *progress) set(name string, state domainState) {
+	p.mu.Lock()
+	p.states[name] = state
+	p.mu.Unlock()
+}
 
-		if verbose {
-			log.Printf("Checking: %s", domain.Name)
+func (p *progress) render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lines := make([]string, len(p.order))
+	for i, name := range p.order {
+		var label string
+		switch p.states[name] {
+		case statePending:
+			label = "pending"
+		case stateInFlight:
+			label = "in-flight"
+		case stateDone:
+			label = "done"
 		}
+		lines[i] = fmt.Sprintf("%s: %s", name, label)
+	}
+	return strings.Join(lines, "\n")
+}
 
-		if checkConnectivity(client, finalHost) {
-			status = "active"
-			cloud, service = detectCloudProvider(ipAddress)
-		}
+// renderProgress repaints one line per domain until done is closed,
+// moving the cursor back up over its own previous output each tick. It
+// always writes to stderr, never stdout, since stdout carries the
+// actual check results (table or otherwise) and must stay clean for
+// piping into jq, a file, etc.
+func renderProgress(p *progress, done <-chan struct{}) {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
 
-		close(done)
+	lines := len(p.order)
+	up := fmt.Sprintf("\033[%dA", lines)
 
-		portResults := checkPorts(ipAddress, domain.Ports)
+	first := true
+	repaint := func() {
+		if !first {
+			fmt.Fprint(os.Stderr, up)
+		}
+		first = false
+		fmt.Fprintf(os.Stderr, "\033[J%s\n", p.render())
+	}
 
-		mxRecord, err := getMXRecord(domain.Name)
-		smtpResult := "-"
-		if err == nil && len(mxRecord) > 0 {
-			if checkSMTP(mxRecord[0]) {
-				smtpResult = "open"
+	for {
+		select {
+		case <-done:
+			if !first {
+				fmt.Fprint(os.Stderr, up)
 			}
+			fmt.Fprint(os.Stderr, "\033[J")
+			return
+		case <-ticker.C:
+			repaint()
 		}
+	}
+}
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// clientFor returns shared unless the domain overrides the timeout or
+// redirect policy, in which case it builds a dedicated client — those
+// settings live on http.Client, not per-request.
+func clientFor(domain Domain, shared *http.Client) *http.Client {
+	if domain.Timeout == "" && domain.FollowRedirects == nil {
+		return shared
+	}
 
-		result := map[string]string{
-			"Domain":  domain.Name,
-			"Status":  status,
-			"IP":      ipAddress,
-			"Cloud":   cloud,
-			"Service": service,
-			"SMTP":    smtpResult,
+	timeout := shared.Timeout
+	if domain.Timeout != "" {
+		if d, err := time.ParseDuration(domain.Timeout); err == nil {
+			timeout = d
+		} else {
+			logger.Warn().Str("domain", domain.Name).Str("timeout", domain.Timeout).Err(err).Msg("invalid timeout, using default")
 		}
-		for portName, res := range portResults {
-			result[portName] = res
+	}
+
+	client := &http.Client{Timeout: timeout}
+	if domain.FollowRedirects != nil && !*domain.FollowRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
 		}
+	}
+	return client
+}
+
+func checkDomains(ctx context.Context, domains []Domain) []output.Result {
+	client := &http.Client{Timeout: defaultHTTPTimeout}
+
+	jobs := make(chan Domain)
+	resultCh := make(chan output.Result)
 
+	p := newProgress(domains)
+	renderDone := make(chan struct{})
+	go renderProgress(p, renderDone)
+
+	workers := concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for domain := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				p.set(domain.Name, stateInFlight)
+				resultCh <- checkDomain(ctx, client, domain)
+				p.set(domain.Name, stateDone)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, domain := range domains {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- domain:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	var results []output.Result
+	for result := range resultCh {
 		results = append(results, result)
 	}
+
+	// Workers finish in whatever order the network allows; put results
+	// back in the order the domains were listed.
+	position := make(map[string]int, len(domains))
+	for i, d := range domains {
+		if _, seen := position[d.Name]; !seen {
+			position[d.Name] = i
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return position[results[i].Domain] < position[results[j].Domain]
+	})
+
+	close(renderDone)
+
 	return results
 }
 
-func getIpAddress(domain string) (string, string) {
-	ips, err := net.LookupIP(domain)
+func checkDomain(ctx context.Context, client *http.Client, domain Domain) output.Result {
+	logger.Debug().Str("domain", domain.Name).Msg("checking")
+
+	r := resolverFor(domain)
+	ipAddress, finalHost := getIpAddress(ctx, domain.Name, r)
+	logResolverDiff(ctx, domain.Name, r)
+
+	status := "deactive"
+	cloud := "unknown"
+	service := "unknown"
+	var tlsInfo *output.TLSInfo
+
+	if checkConnectivity(ctx, clientFor(domain, client), domain, finalHost) {
+		status = "active"
+		if !domain.skips("cloud") {
+			cloud, service = detectCloudProvider(ctx, ipAddress)
+		}
+		tlsInfo = inspectTLS(ctx, finalHost)
+	}
+
+	portResults := checkPorts(ctx, ipAddress, domain.Ports)
+
+	smtpResult := "-"
+	if !domain.skips("smtp") {
+		mxRecord, err := getMXRecord(ctx, domain.Name, r)
+		if err == nil && len(mxRecord) > 0 {
+			if checkSMTP(ctx, mxRecord[0]) {
+				smtpResult = "open"
+			}
+		}
+	}
+
+	return output.Result{
+		Domain:  domain.Name,
+		Status:  status,
+		IP:      ipAddress,
+		Cloud:   cloud,
+		Service: service,
+		SMTP:    smtpResult,
+		TLS:     tlsInfo,
+		Ports:   portResults,
+	}
+}
+
+func getIpAddress(ctx context.Context, domain string, r resolver.Resolver) (string, string) {
+	ips, err := r.LookupA(ctx, domain)
 	if err == nil {
 		for _, ip := range ips {
 			if ipv4 := ip.To4(); ipv4 != nil {
+				logger.Debug().Str("domain", domain).Str("resolver", r.String()).Str("ip", ipv4.String()).Msg("resolved")
 				return ipv4.String(), domain
 			}
 		}
 	}
 
-	cname, err := net.LookupCNAME(domain)
+	cname, err := r.LookupCNAME(ctx, domain)
 	if err == nil && cname != domain+"." {
-		if verbose {
-			log.Printf("CNAME found: %s â†’ %s", domain, cname)
-		}
+		logger.Debug().Str("domain", domain).Str("cname", cname).Str("resolver", r.String()).Msg("cname found")
 
-		ips, err := net.LookupIP(cname)
-		if err != nil {
+		ips, err := r.LookupA(ctx, cname)
+		if err == nil {
 			for _, ip := range ips {
 				if ipv4 := ip.To4(); ipv4 != nil {
+					logger.Debug().Str("domain", domain).Str("cname", cname).Str("resolver", r.String()).Str("ip", ipv4.String()).Msg("resolved")
 					return ipv4.String(), domain
 				}
 			}
 		}
 	}
 
-	if verbose {
-		log.Printf("Failed to resolve domain:%s (%v)", domain, err)
-	}
+	logger.Debug().Str("domain", domain).Str("resolver", r.String()).Err(err).Msg("failed to resolve domain")
 
 	return "N/A", domain
 }
 
-func checkConnectivity(client *http.Client, finalHost string) bool {
+func checkConnectivity(ctx context.Context, client *http.Client, domain Domain, finalHost string) bool {
 
 	urls := []string{
-		"https://" + finalHost,
-		"http://" + finalHost,
+		"https://" + finalHost + domain.path(),
+		"http://" + finalHost + domain.path(),
 	}
 
 	for _, url := range urls {
 
-		req, _ := http.NewRequest("HEAD", url, nil)
+		req, _ := http.NewRequestWithContext(ctx, "HEAD", url, nil)
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+		applyHeaders(req, domain.Headers)
 
 		resp, err := client.Do(req)
 		if err != nil {
-			if verbose {
-				log.Printf("Failed: %s (%v)", url, err)
-			}
+			logger.Debug().Str("url", url).Err(err).Msg("head probe failed")
 		} else {
 			defer resp.Body.Close()
-			if resp.StatusCode < 400 {
-				if verbose {
-					log.Printf("HEAD Success: %s (Status: %d)", url, resp.StatusCode)
-				}
+			if domain.expectedStatus(resp.StatusCode) {
+				logger.Debug().Str("url", url).Int("status", resp.StatusCode).Msg("head probe succeeded")
 				return true
 			}
 		}
 
-		req, _ = http.NewRequest("GET", url, nil)
+		req, _ = http.NewRequestWithContext(ctx, "GET", url, nil)
 		req.Header.Set("User-Agent", "Mozilla/5.0")
+		applyHeaders(req, domain.Headers)
 
 		resp, err = client.Do(req)
 		if err != nil {
-			if verbose {
-				log.Printf("GET Failed: %s (%v)", url, err)
-			}
+			logger.Debug().Str("url", url).Err(err).Msg("get probe failed")
 			continue
 		}
 		defer resp.Body.Close()
 
-		if resp.StatusCode < 400 {
-			if verbose {
-				log.Printf("GET Success: %s (Status: %d)", url, resp.StatusCode)
-			}
+		if domain.expectedStatus(resp.StatusCode) {
+			logger.Debug().Str("url", url).Int("status", resp.StatusCode).Msg("get probe succeeded")
 			return true
 		}
 	}
 	return false
 }
 
-func checkPorts(ip string, ports map[string]int) map[string]string {
+// applyHeaders sets each configured header on req, special-casing Host
+// since http.Request takes that from a dedicated field rather than its
+// Header map.
+func applyHeaders(req *http.Request, headers map[string]string) {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+}
+
+func checkPorts(ctx context.Context, ip string, ports map[string]int) map[string]string {
 	results := make(map[string]string)
 	for name, port := range ports {
-		if checkPortOpen(ip, port) {
+		if checkPortOpen(ctx, ip, port) {
 			results[name] = "open"
 		} else {
 			results[name] = "-"
@@ -211,8 +562,12 @@ func checkPorts(ip string, ports map[string]int) map[string]string {
 	return results
 }
 
-func checkPortOpen(ip string, port int) bool {
-	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", ip, port), 2*time.Second)
+func checkPortOpen(ctx context.Context, ip string, port int) bool {
+	var d net.Dialer
+	dialCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	conn, err := d.DialContext(dialCtx, "tcp", fmt.Sprintf("%s:%d", ip, port))
 	if err != nil {
 		return false
 	}
@@ -220,27 +575,23 @@ func checkPortOpen(ip string, port int) bool {
 	return true
 }
 
-func getMXRecord(domain string) ([]string, error) {
-	mxRecord, err := net.LookupMX(domain)
+func getMXRecord(ctx context.Context, domain string, r resolver.Resolver) ([]string, error) {
+	hosts, err := r.LookupMX(ctx, domain)
 	if err != nil {
-		if verbose {
-			log.Printf("Failed to lookup MX for %s: %v", domain, err)
-		}
+		logger.Debug().Str("domain", domain).Str("resolver", r.String()).Err(err).Msg("failed to look up MX record")
 		return nil, err
 	}
-	var hosts []string
-	for _, mx := range mxRecord {
-		hosts = append(hosts, mx.Host)
-	}
 	return hosts, nil
 }
 
-func checkSMTP(host string) bool {
-	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, "25"), 5*time.Second)
+func checkSMTP(ctx context.Context, host string) bool {
+	var d net.Dialer
+	dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	conn, err := d.DialContext(dialCtx, "tcp", net.JoinHostPort(host, "25"))
 	if err != nil {
-		if verbose {
-			log.Printf("Failed to connect SMTP: %s (%v)", host, err)
-		}
+		logger.Debug().Str("host", host).Err(err).Msg("failed to connect to SMTP")
 		return false
 	}
 	defer conn.Close()
@@ -258,208 +609,31 @@ func checkSMTP(host string) bool {
 
 }
 
-func detectCloudProvider(ip string) (cloud, serive string) {
-	if detectedService := getAWSService(ip); detectedService != "" {
-		return "AWS", detectedService
-	}
-
-	addrs, err := net.LookupHost(ip)
-	if err != nil {
+func detectCloudProvider(ctx context.Context, ip string) (cloud, service string) {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
 		return "unknown", "unknown"
 	}
 
-	for _, addr := range addrs {
-		if isGCPIP(addr) {
-			return "GCP", "unknown"
-		} else if isAzureIP(addr) {
-			return "Azure", "unknown"
-		}
-	}
-	return "unkown", "unknown"
-}
-
-func getAWSService(ip string) string {
-	resp, err := http.Get("https://ip-ranges.amazonaws.com/ip-ranges.json")
-	if err != nil {
-		if verbose {
-			log.Printf("Failed to fetch AWS IP ranges: %v", err)
-		}
-		return ""
+	if awsSet, err := rangesCache.Get(ctx, ipranges.AWS); err != nil {
+		logger.Debug().Err(err).Msg("failed to load AWS IP ranges")
+	} else if r, ok := awsSet.Lookup(parsedIP); ok {
+		return "AWS", r.Service
 	}
-	defer resp.Body.Close()
 
-	var data struct {
-		Prefixes []struct {
-			IPPrefix string `json:"ip_prefix"`
-			Service  string `json:"service"`
-		} `json:"prefixes"`
+	if gcpSet, err := rangesCache.Get(ctx, ipranges.GCP); err != nil {
+		logger.Debug().Err(err).Msg("failed to load GCP IP ranges")
+	} else if _, ok := gcpSet.Lookup(parsedIP); ok {
+		return "GCP", "unknown"
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return ""
+	if isAzureIP(ip) {
+		return "Azure", "unknown"
 	}
 
-	parsedIP := net.ParseIP(ip)
-	for _, prefix := range data.Prefixes {
-		_, ipNet, err := net.ParseCIDR(prefix.IPPrefix)
-		if err != nil {
-			continue
-		}
-		if ipNet.Contains(parsedIP) {
-			if prefix.Service == "AMAZON" {
-				return "shared"
-			}
-			return prefix.Service
-		}
-	}
-	return ""
-}
-
-func isGCPIP(ip string) bool {
-	return checkIpInRanges(ip, "https://www.gstatic.com/ipranges/cloud.json", "prefixes", "ipv4Prefix")
+	return "unknown", "unknown"
 }
 
 func isAzureIP(ip string) bool {
 	return strings.HasPrefix(ip, "20.") || strings.HasPrefix(ip, "40.") || strings.HasPrefix(ip, "52.")
 }
-
-func checkIpInRanges(ip, url, arrayKey, ipKey string) bool {
-	resp, err := http.Get(url)
-	if err != nil {
-		if verbose {
-			log.Printf("Failed to fetch IP ranges from %s: %v", url, err)
-		}
-		return false
-	}
-	defer resp.Body.Close()
-
-	var raw map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-		return false
-	}
-
-	ranges, ok := raw[arrayKey].([]any)
-	if !ok {
-		return false
-	}
-
-	parsedIP := net.ParseIP(ip)
-	for _, r := range ranges {
-		rmap := r.(map[string]any)
-		cidr, ok := rmap[ipKey].(string)
-		if !ok {
-			continue
-		}
-		_, ipNet, err := net.ParseCIDR(cidr)
-		if err != nil {
-			continue
-		}
-		if ipNet.Contains(parsedIP) {
-			return true
-		}
-	}
-	return false
-}
-
-func showLoading(domain string, done <-chan struct{}) {
-	frames := []string{"/", "-", "\\", "|"}
-	fmt.Printf("fetching %s ", domain)
-
-	ticker := time.NewTimer(50 * time.Millisecond)
-	defer ticker.Stop()
-
-	for i := 0; ; i = (i + 1) % len(frames) {
-		select {
-		case <-done:
-			fmt.Printf("\r\033[K")
-			return
-		case <-ticker.C:
-			fmt.Printf("\rfetching %s ...%s", domain, frames[i])
-		}
-	}
-}
-
-const (
-	colorGreen  = "\033[32m"
-	colorRed    = "\033[31m"
-	colorYellow = "\033[33m"
-	colorCyan   = "\033[36m"
-	colorBlue   = "\033[34m"
-	colorReset  = "\033[0m"
-)
-
-func colorizeStatus(status string) string {
-	switch status {
-	case "active":
-		return colorGreen + status + colorReset
-	case "deactive":
-		return colorRed + status + colorReset
-	default:
-		return status
-	}
-}
-
-func colorizeCloud(cloud string) string {
-	switch cloud {
-	case "AWS":
-		return colorYellow + cloud + colorReset
-	case "Azure":
-		return colorCyan + cloud + colorReset
-	case "GCP":
-		return colorBlue + cloud + colorReset
-	default:
-		return cloud
-	}
-}
-
-func displayResults(domains []Domain, results []map[string]string) {
-	table := tablewriter.NewWriter(os.Stdout)
-
-	allPorts := collectAllPorts(domains)
-
-	header := []string{"Domain", "Status", "Cloud", "Service", "IP", "SMTP"}
-
-	for _, portName := range allPorts {
-		header = append(header, strings.ToUpper(portName))
-	}
-	table.SetHeader(header)
-
-	for _, result := range results {
-		row := []string{
-			result["Domain"],
-			colorizeStatus(result["Status"]),
-			colorizeCloud(result["Cloud"]),
-			result["Service"],
-			result["IP"],
-			result["SMTP"],
-		}
-		for _, portName := range allPorts {
-			if value, exists := result[portName]; exists {
-				row = append(row, value)
-			} else {
-				row = append(row, "undefined")
-			}
-		}
-		table.Append(row)
-	}
-
-	table.Render()
-}
-
-func collectAllPorts(domains []Domain) []string {
-	portSet := make(map[string]struct{})
-	for _, domain := range domains {
-		for portName := range domain.Ports {
-			portSet[portName] = struct{}{}
-		}
-	}
-
-	var allPorts []string
-	for portName := range portSet {
-		allPorts = append(allPorts, portName)
-	}
-
-	sort.Strings(allPorts)
-
-	return allPorts
-}
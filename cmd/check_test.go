@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"net"
 	"testing"
+
+	"github.com/7csc/domain-checker/internal/resolver"
 )
 
 func TestGetIpAddress(t *testing.T) {
@@ -15,8 +18,9 @@ func TestGetIpAddress(t *testing.T) {
 		{"not-found.domain", "N/A", true},
 	}
 
+	ctx := context.Background()
 	for _, tt := range tests {
-		gotIP, _ := getIpAddress(tt.domain)
+		gotIP, _ := getIpAddress(ctx, tt.domain, resolver.System{})
 
 		if tt.shouldError && gotIP != "N/A" {
 			t.Errorf("Expected N/A for %s, but got %s", tt.domain, gotIP)
@@ -33,35 +37,12 @@ func TestCheckPortOpen(t *testing.T) {
 	}
 	defer listener.Close()
 
-	if !checkPortOpen("localhost", 8081) {
+	ctx := context.Background()
+	if !checkPortOpen(ctx, "localhost", 8081) {
 		t.Errorf("Expected port 8081 to be open, but checkPortOpen() returned false")
 	}
 
-	if checkPortOpen("localhost", 9999) {
+	if checkPortOpen(ctx, "localhost", 9999) {
 		t.Errorf("Expected port 9999 to be closed, but checkPortOpen() returned true")
 	}
 }
-
-func TestColorizeStatus(t *testing.T) {
-	if colorizeStatus("active") != colorGreen+"active"+colorReset {
-		t.Errorf("Color for 'active' is incorrect")
-	}
-	if colorizeStatus("deactive") != colorRed+"deactive"+colorReset {
-		t.Errorf("Color for 'deactive' is incorrect")
-	}
-}
-
-func TestColorizeCloud(t *testing.T) {
-	if colorizeCloud("AWS") != colorYellow+"AWS"+colorReset {
-		t.Errorf("Color for 'AWS' is incorrect")
-	}
-	if colorizeCloud("Azure") != colorCyan+"Azure"+colorReset {
-		t.Errorf("Color for 'Azure' is incorrect")
-	}
-	if colorizeCloud("GCP") != colorBlue+"GCP"+colorReset {
-		t.Errorf("Color for 'GCP' is incorrect")
-	}
-	if colorizeCloud("unknown") != "unknown" {
-		t.Errorf("Color for 'unknown' is incorrect")
-	}
-}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	logLevel    string
+	logFormat   string
+	logFilePath string
+
+	logger zerolog.Logger
+)
+
+// setupLogger builds the package-level logger from --log-level,
+// --log-format, and --log-file. It runs as a PersistentPreRunE on
+// rootCmd, so it sees --verbose on whichever subcommand set it.
+func setupLogger() error {
+	level, err := zerolog.ParseLevel(logLevel)
+	if err != nil {
+		return err
+	}
+	if verbose {
+		level = zerolog.DebugLevel
+	}
+
+	var out io.Writer = os.Stderr
+	if logFilePath != "" {
+		f, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		out = f
+	}
+
+	if logFormat == "console" {
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339}
+	}
+
+	logger = zerolog.New(out).Level(level).With().Timestamp().Logger()
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "doch",
 	Short: "Domain Checker CLI",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return setupLogger()
+	},
 }
 
 func Execute() {
@@ -18,5 +21,9 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level: trace, debug, info, warn, error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "console", "Log format: console, json")
+	rootCmd.PersistentFlags().StringVar(&logFilePath, "log-file", "", "Write logs to this file instead of stderr")
+
 	rootCmd.AddCommand(checkCmd)
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/7csc/domain-checker/internal/output"
+)
+
+// inspectTLS dials host:443, verifying the certificate chain against the
+// system roots, and reports the leaf certificate's expiry, issuer, and
+// whether it's actually valid for host.
+func inspectTLS(ctx context.Context, host string) *output.TLSInfo {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config: &tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: false,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, "443"))
+	if err != nil {
+		logger.Debug().Str("host", host).Err(err).Msg("TLS handshake failed")
+		return &output.TLSInfo{Error: err.Error()}
+	}
+	defer conn.Close()
+
+	leaf := conn.(*tls.Conn).ConnectionState().PeerCertificates[0]
+
+	return &output.TLSInfo{
+		NotAfter:   leaf.NotAfter,
+		Issuer:     leaf.Issuer.CommonName,
+		SANMatch:   leaf.VerifyHostname(host) == nil,
+		ChainValid: true,
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/7csc/domain-checker/internal/resolver"
+)
+
+var validateFilePath string
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a domains TOML file",
+	Long: `Validate a domains TOML file.
+
+Syntax errors are reported with the line and column go-toml's parser
+found them at. Semantic errors (bad timeout, unknown resolver scheme,
+unrecognized skip value, ...) are reported by domain index and name
+only — go-toml doesn't retain position info once a file decodes
+successfully, so there's no line number to attach to those.`,
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().StringVarP(&validateFilePath, "file", "f", "domains.toml", "Path to TOML file")
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(validateFilePath)
+	if err != nil {
+		return err
+	}
+
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			return fmt.Errorf("%s: %s", validateFilePath, decodeErr.String())
+		}
+		return fmt.Errorf("%s: %w", validateFilePath, err)
+	}
+
+	// Unlike the toml.DecodeError above, these errors carry no line/column —
+	// the file already parsed fine by this point, so domain index and name
+	// are the best locator we have.
+	for i, domain := range config.Domains {
+		if err := validateDomain(domain); err != nil {
+			return fmt.Errorf("%s: domains[%d] (%s): %w", validateFilePath, i, domain.Name, err)
+		}
+	}
+
+	fmt.Printf("%s is valid (%d domains)\n", validateFilePath, len(config.Domains))
+	return nil
+}
+
+func validateDomain(domain Domain) error {
+	if domain.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if domain.Timeout != "" {
+		if _, err := time.ParseDuration(domain.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", domain.Timeout, err)
+		}
+	}
+
+	if domain.Resolver != "" {
+		if _, err := resolver.Parse(domain.Resolver); err != nil {
+			return fmt.Errorf("invalid resolver: %w", err)
+		}
+	}
+
+	for _, s := range domain.Skip {
+		if s != "smtp" && s != "cloud" {
+			return fmt.Errorf("unknown skip value %q (expected \"smtp\" or \"cloud\")", s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package ipranges fetches and caches the published IP range files for
+// cloud providers (AWS, GCP) so that a single invocation of doch doesn't
+// refetch a multi-megabyte JSON document once per domain.
+package ipranges
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Provider identifies which cloud's range file an entry caches.
+type Provider string
+
+const (
+	AWS Provider = "aws"
+	GCP Provider = "gcp"
+)
+
+// entry is the on-disk and in-memory representation of a single
+// provider's cached range file, including enough metadata to make a
+// conditional GET on the next refresh.
+type entry struct {
+	ETag           string `json:"etag"`
+	LastModified   string `json:"last_modified"`
+	ExpiresEpochMs int64  `json:"expires_epoch_ms"`
+	Set            *Set   `json:"set"`
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return now.UnixMilli() >= e.ExpiresEpochMs
+}
+
+// Cache is an expiring, disk-backed store of parsed provider range
+// sets, keyed by Provider. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	dir     string
+	entries map[Provider]*entry
+}
+
+// NewCache builds a Cache that persists entries under dir (typically
+// $XDG_CACHE_HOME/doch) and treats a fetched entry as fresh for ttl.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		dir:     dir,
+		entries: make(map[Provider]*entry),
+	}
+}
+
+// DefaultCacheDir resolves $XDG_CACHE_HOME/doch, falling back to the
+// user cache directory reported by the OS.
+func DefaultCacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "doch"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "doch"), nil
+}
+
+func (c *Cache) path(p Provider) string {
+	return filepath.Join(c.dir, "ranges-"+string(p)+".json")
+}
+
+func (c *Cache) load(p Provider) *entry {
+	if e, ok := c.entries[p]; ok {
+		return e
+	}
+
+	data, err := os.ReadFile(c.path(p))
+	if err != nil {
+		return nil
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil
+	}
+	if e.Set != nil {
+		e.Set.hydrate()
+	}
+
+	c.entries[p] = &e
+	return &e
+}
+
+func (c *Cache) store(p Provider, e *entry) error {
+	c.entries[p] = e
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path(p), data, 0o644)
+}
+
+// Clear removes every cached entry, both in memory and on disk.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[Provider]*entry)
+	return os.RemoveAll(c.dir)
+}
@@ -0,0 +1,146 @@
+package ipranges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var sourceURL = map[Provider]string{
+	AWS: "https://ip-ranges.amazonaws.com/ip-ranges.json",
+	GCP: "https://www.gstatic.com/ipranges/cloud.json",
+}
+
+// Get returns the cached Set for p, transparently refreshing it if the
+// entry is missing or past its TTL. A cache hit costs nothing more than
+// a map lookup.
+func (c *Cache) Get(ctx context.Context, p Provider) (*Set, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.load(p)
+	if e != nil && !e.expired(time.Now()) {
+		return e.Set, nil
+	}
+
+	return c.refreshLocked(ctx, p, e)
+}
+
+// Refresh forces a conditional re-fetch of p's range file regardless of
+// TTL, reusing the stored ETag/Last-Modified so an unchanged upstream
+// file costs only a 304.
+func (c *Cache) Refresh(ctx context.Context, p Provider) (*Set, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.refreshLocked(ctx, p, c.load(p))
+}
+
+func (c *Cache) refreshLocked(ctx context.Context, p Provider, prev *entry) (*Set, error) {
+	url, ok := sourceURL[p]
+	if !ok {
+		return nil, fmt.Errorf("ipranges: unknown provider %q", p)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if prev != nil {
+			return prev.Set, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		prev.ExpiresEpochMs = time.Now().Add(c.ttl).UnixMilli()
+		if err := c.store(p, prev); err != nil {
+			return prev.Set, err
+		}
+		return prev.Set, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if prev != nil {
+			return prev.Set, nil
+		}
+		return nil, fmt.Errorf("ipranges: fetching %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	set, err := parse(p, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{
+		ETag:           resp.Header.Get("ETag"),
+		LastModified:   resp.Header.Get("Last-Modified"),
+		ExpiresEpochMs: time.Now().Add(c.ttl).UnixMilli(),
+		Set:            set,
+	}
+	if err := c.store(p, e); err != nil {
+		return set, err
+	}
+
+	return set, nil
+}
+
+func parse(p Provider, body io.Reader) (*Set, error) {
+	switch p {
+	case AWS:
+		var data struct {
+			Prefixes []struct {
+				IPPrefix string `json:"ip_prefix"`
+				Service  string `json:"service"`
+			} `json:"prefixes"`
+		}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return nil, err
+		}
+		ranges := make([]Range, 0, len(data.Prefixes))
+		for _, prefix := range data.Prefixes {
+			service := prefix.Service
+			if service == "AMAZON" {
+				service = "shared"
+			}
+			ranges = append(ranges, Range{Network: prefix.IPPrefix, Service: service})
+		}
+		return NewSet(ranges), nil
+
+	case GCP:
+		var data struct {
+			Prefixes []struct {
+				IPv4Prefix string `json:"ipv4Prefix"`
+			} `json:"prefixes"`
+		}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return nil, err
+		}
+		ranges := make([]Range, 0, len(data.Prefixes))
+		for _, prefix := range data.Prefixes {
+			if prefix.IPv4Prefix == "" {
+				continue
+			}
+			ranges = append(ranges, Range{Network: prefix.IPv4Prefix})
+		}
+		return NewSet(ranges), nil
+
+	default:
+		return nil, fmt.Errorf("ipranges: unknown provider %q", p)
+	}
+}
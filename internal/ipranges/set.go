@@ -0,0 +1,93 @@
+package ipranges
+
+import (
+	"encoding/binary"
+	"net"
+	"sort"
+)
+
+// Range is a single CIDR block belonging to a provider, optionally
+// tagged with the sub-service that owns it (AWS only; GCP leaves this
+// blank).
+type Range struct {
+	Network string `json:"network"`
+	Service string `json:"service,omitempty"`
+
+	net *net.IPNet
+}
+
+// Set is a provider's parsed range file, kept sorted by network start
+// address so Lookup can binary-search for the range containing an
+// address in O(log n) instead of scanning linearly per domain.
+type Set struct {
+	Ranges []Range `json:"ranges"`
+}
+
+// NewSet parses and sorts the given CIDR/service pairs.
+func NewSet(ranges []Range) *Set {
+	parsed := ranges[:0:0]
+	for _, r := range ranges {
+		_, ipNet, err := net.ParseCIDR(r.Network)
+		if err != nil {
+			continue
+		}
+		r.net = ipNet
+		parsed = append(parsed, r)
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		return ipToUint32(parsed[i].net.IP) < ipToUint32(parsed[j].net.IP)
+	})
+
+	return &Set{Ranges: parsed}
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	v4 := ip.To4()
+	if v4 == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(v4)
+}
+
+// Lookup returns the range containing ip, if any. Only the range with
+// the highest start address at or below ip is checked, so when ranges
+// overlap, a broader range enclosing ip is not found and the result is
+// not necessarily the longest prefix match.
+func (s *Set) Lookup(ip net.IP) (Range, bool) {
+	if s == nil {
+		return Range{}, false
+	}
+
+	key := ipToUint32(ip)
+
+	// Provider range files are non-overlapping, so the only candidate
+	// whose start address is <= key is the one immediately before the
+	// first network past key. No backward scan needed.
+	idx := sort.Search(len(s.Ranges), func(i int) bool {
+		return ipToUint32(s.Ranges[i].net.IP) > key
+	})
+
+	if idx == 0 {
+		return Range{}, false
+	}
+
+	r := s.Ranges[idx-1]
+	if r.net != nil && r.net.Contains(ip) {
+		return r, true
+	}
+	return Range{}, false
+}
+
+// hydrate re-parses the CIDR strings after a Set has been round-tripped
+// through JSON, since the unexported net.IPNet cache doesn't survive
+// (de)serialization.
+func (s *Set) hydrate() {
+	for i := range s.Ranges {
+		_, ipNet, err := net.ParseCIDR(s.Ranges[i].Network)
+		if err != nil {
+			continue
+		}
+		s.Ranges[i].net = ipNet
+	}
+}
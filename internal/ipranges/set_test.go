@@ -0,0 +1,40 @@
+package ipranges
+
+import (
+	"net"
+	"testing"
+)
+
+func TestSetLookup(t *testing.T) {
+	set := NewSet([]Range{
+		{Network: "10.0.0.0/24", Service: "first"},
+		{Network: "10.0.1.0/24", Service: "second"},
+	})
+
+	if r, ok := set.Lookup(net.ParseIP("10.0.0.5")); !ok || r.Service != "first" {
+		t.Errorf("expected 10.0.0.5 to match %q, got %+v, %v", "first", r, ok)
+	}
+
+	if r, ok := set.Lookup(net.ParseIP("10.0.1.5")); !ok || r.Service != "second" {
+		t.Errorf("expected 10.0.1.5 to match %q, got %+v, %v", "second", r, ok)
+	}
+
+	if _, ok := set.Lookup(net.ParseIP("10.0.0.255")); !ok {
+		t.Errorf("expected 10.0.0.255 (last address in range) to match")
+	}
+
+	if _, ok := set.Lookup(net.ParseIP("10.0.2.0")); ok {
+		t.Errorf("expected 10.0.2.0 (just past both ranges) not to match")
+	}
+
+	if _, ok := set.Lookup(net.ParseIP("9.255.255.255")); ok {
+		t.Errorf("expected 9.255.255.255 (just before both ranges) not to match")
+	}
+}
+
+func TestSetLookupNilSet(t *testing.T) {
+	var set *Set
+	if _, ok := set.Lookup(net.ParseIP("10.0.0.1")); ok {
+		t.Errorf("expected lookup on a nil Set to report no match")
+	}
+}
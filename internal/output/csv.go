@@ -0,0 +1,62 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvOutputter struct{}
+
+func (csvOutputter) Write(w io.Writer, results []Result) error {
+	ports := allPorts(results)
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{"domain", "status", "cloud", "service", "ip", "smtp", "tls_expires", "tls_valid"}
+	for _, portName := range ports {
+		header = append(header, strings.ToLower(portName))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		row := []string{
+			result.Domain,
+			result.Status,
+			result.Cloud,
+			result.Service,
+			result.IP,
+			result.SMTP,
+			tlsExpiresCSV(result.TLS),
+			tlsValidCSV(result.TLS),
+		}
+		for _, portName := range ports {
+			row = append(row, result.Ports[portName])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
+
+func tlsExpiresCSV(info *TLSInfo) string {
+	if info == nil || info.NotAfter.IsZero() {
+		return ""
+	}
+	return info.NotAfter.Format("2006-01-02")
+}
+
+func tlsValidCSV(info *TLSInfo) string {
+	if info == nil {
+		return ""
+	}
+	if info.Valid() {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,14 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonOutputter struct{}
+
+func (jsonOutputter) Write(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
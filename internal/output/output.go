@@ -0,0 +1,87 @@
+// Package output renders domain check results in a handful of formats
+// (table, json, yaml, csv, prom) behind a single Outputter interface so
+// callers don't need to know which one they asked for.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// TLSInfo is the outcome of inspecting a domain's certificate on port
+// 443. Error is set (and the other fields left zero) when the TLS
+// handshake itself failed, e.g. the chain didn't verify or the host
+// refused the connection.
+type TLSInfo struct {
+	NotAfter   time.Time `json:"not_after,omitempty" yaml:"not_after,omitempty"`
+	Issuer     string    `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	SANMatch   bool      `json:"san_match" yaml:"san_match"`
+	ChainValid bool      `json:"chain_valid" yaml:"chain_valid"`
+	Error      string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Valid reports whether the certificate is usable: the handshake
+// succeeded, the chain verified, and a SAN matched the domain.
+func (t *TLSInfo) Valid() bool {
+	return t != nil && t.Error == "" && t.ChainValid && t.SANMatch
+}
+
+// Result is the typed, per-domain outcome of a check. Every formatter
+// renders from this shape so output is deterministic regardless of
+// which one is chosen.
+type Result struct {
+	Domain  string            `json:"domain" yaml:"domain"`
+	Status  string            `json:"status" yaml:"status"`
+	IP      string            `json:"ip" yaml:"ip"`
+	Cloud   string            `json:"cloud" yaml:"cloud"`
+	Service string            `json:"service" yaml:"service"`
+	SMTP    string            `json:"smtp" yaml:"smtp"`
+	TLS     *TLSInfo          `json:"tls,omitempty" yaml:"tls,omitempty"`
+	Ports   map[string]string `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// Outputter renders a set of Results to w.
+type Outputter interface {
+	Write(w io.Writer, results []Result) error
+}
+
+// New resolves a format name (table, json, yaml, csv, prom) to its
+// Outputter. It is the only place callers need to list the supported
+// formats.
+func New(format string) (Outputter, error) {
+	switch format {
+	case "", "table":
+		return tableOutputter{}, nil
+	case "json":
+		return jsonOutputter{}, nil
+	case "yaml":
+		return yamlOutputter{}, nil
+	case "csv":
+		return csvOutputter{}, nil
+	case "prom":
+		return promOutputter{}, nil
+	default:
+		return nil, fmt.Errorf("output: unknown format %q", format)
+	}
+}
+
+// allPorts returns the sorted, de-duplicated set of port names present
+// across results, so table/csv/prom formatters can render a stable set
+// of columns/series even when domains define different ports.
+func allPorts(results []Result) []string {
+	portSet := make(map[string]struct{})
+	for _, result := range results {
+		for portName := range result.Ports {
+			portSet[portName] = struct{}{}
+		}
+	}
+
+	ports := make([]string, 0, len(portSet))
+	for portName := range portSet {
+		ports = append(ports, portName)
+	}
+	sort.Strings(ports)
+	return ports
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// promOutputter renders Prometheus textfile-collector exposition format
+// so `doch check -o prom --output-file` can feed node_exporter directly.
+type promOutputter struct{}
+
+func (promOutputter) Write(w io.Writer, results []Result) error {
+	fmt.Fprintln(w, "# HELP doch_domain_up Whether the domain responded to an HTTP probe (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE doch_domain_up gauge")
+	for _, result := range results {
+		fmt.Fprintf(w, "doch_domain_up{domain=%q} %d\n", result.Domain, boolToFloat(result.Status == "active"))
+	}
+
+	fmt.Fprintln(w, "# HELP doch_port_open Whether a configured port is open (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE doch_port_open gauge")
+	for _, result := range results {
+		for _, portName := range allPorts(results) {
+			value, exists := result.Ports[portName]
+			if !exists {
+				continue
+			}
+			fmt.Fprintf(w, "doch_port_open{domain=%q,port=%q} %d\n", result.Domain, portName, boolToFloat(value == "open"))
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP doch_tls_cert_expiry_seconds Seconds until the domain's TLS certificate expires.")
+	fmt.Fprintln(w, "# TYPE doch_tls_cert_expiry_seconds gauge")
+	for _, result := range results {
+		if result.TLS == nil || result.TLS.NotAfter.IsZero() {
+			continue
+		}
+		fmt.Fprintf(w, "doch_tls_cert_expiry_seconds{domain=%q} %d\n", result.Domain, int64(time.Until(result.TLS.NotAfter).Seconds()))
+	}
+
+	fmt.Fprintln(w, "# HELP doch_tls_valid Whether the domain's TLS chain and SAN validated (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE doch_tls_valid gauge")
+	for _, result := range results {
+		if result.TLS == nil {
+			continue
+		}
+		fmt.Fprintf(w, "doch_tls_valid{domain=%q} %d\n", result.Domain, boolToFloat(result.TLS.Valid()))
+	}
+
+	fmt.Fprintln(w, "# HELP doch_cloud_info Cloud provider and service detected for a domain; always 1 when present.")
+	fmt.Fprintln(w, "# TYPE doch_cloud_info gauge")
+	for _, result := range results {
+		if result.Cloud == "" || result.Cloud == "unknown" {
+			continue
+		}
+		fmt.Fprintf(w, "doch_cloud_info{domain=%q,cloud=%q,service=%q} 1\n", result.Domain, result.Cloud, result.Service)
+	}
+
+	return nil
+}
+
+func boolToFloat(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	colorGreen  = "\033[32m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+	colorBlue   = "\033[34m"
+	colorReset  = "\033[0m"
+)
+
+func colorizeStatus(status string) string {
+	switch status {
+	case "active":
+		return colorGreen + status + colorReset
+	case "deactive":
+		return colorRed + status + colorReset
+	default:
+		return status
+	}
+}
+
+func colorizeCloud(cloud string) string {
+	switch cloud {
+	case "AWS":
+		return colorYellow + cloud + colorReset
+	case "Azure":
+		return colorCyan + cloud + colorReset
+	case "GCP":
+		return colorBlue + cloud + colorReset
+	default:
+		return cloud
+	}
+}
+
+// colorizeTLS renders a cert's remaining validity, colored green when
+// it has more than 30 days left, yellow between 7 and 30, and red when
+// it's under a week out or failed to verify at all.
+func colorizeTLS(info *TLSInfo) string {
+	if info == nil {
+		return "-"
+	}
+	if !info.Valid() {
+		return colorRed + "invalid" + colorReset
+	}
+
+	// Round rather than truncate: a cert built with "3 days left" should
+	// still read as 3d a few milliseconds later, not 2d.
+	days := int(math.Round(time.Until(info.NotAfter).Hours() / 24))
+	label := fmt.Sprintf("%dd", days)
+
+	switch {
+	case days < 7:
+		return colorRed + label + colorReset
+	case days <= 30:
+		return colorYellow + label + colorReset
+	default:
+		return colorGreen + label + colorReset
+	}
+}
+
+type tableOutputter struct{}
+
+func (tableOutputter) Write(w io.Writer, results []Result) error {
+	table := tablewriter.NewWriter(w)
+
+	ports := allPorts(results)
+
+	header := []string{"Domain", "Status", "Cloud", "Service", "IP", "SMTP", "Expires"}
+	for _, portName := range ports {
+		header = append(header, strings.ToUpper(portName))
+	}
+	table.SetHeader(header)
+
+	for _, result := range results {
+		row := []string{
+			result.Domain,
+			colorizeStatus(result.Status),
+			colorizeCloud(result.Cloud),
+			result.Service,
+			result.IP,
+			result.SMTP,
+			colorizeTLS(result.TLS),
+		}
+		for _, portName := range ports {
+			if value, exists := result.Ports[portName]; exists {
+				row = append(row, value)
+			} else {
+				row = append(row, "undefined")
+			}
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	return nil
+}
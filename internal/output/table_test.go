@@ -0,0 +1,51 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestColorizeStatus(t *testing.T) {
+	if colorizeStatus("active") != colorGreen+"active"+colorReset {
+		t.Errorf("Color for 'active' is incorrect")
+	}
+	if colorizeStatus("deactive") != colorRed+"deactive"+colorReset {
+		t.Errorf("Color for 'deactive' is incorrect")
+	}
+}
+
+func TestColorizeCloud(t *testing.T) {
+	if colorizeCloud("AWS") != colorYellow+"AWS"+colorReset {
+		t.Errorf("Color for 'AWS' is incorrect")
+	}
+	if colorizeCloud("Azure") != colorCyan+"Azure"+colorReset {
+		t.Errorf("Color for 'Azure' is incorrect")
+	}
+	if colorizeCloud("GCP") != colorBlue+"GCP"+colorReset {
+		t.Errorf("Color for 'GCP' is incorrect")
+	}
+	if colorizeCloud("unknown") != "unknown" {
+		t.Errorf("Color for 'unknown' is incorrect")
+	}
+}
+
+func TestColorizeTLS(t *testing.T) {
+	if colorizeTLS(nil) != "-" {
+		t.Errorf("Color for nil TLSInfo is incorrect")
+	}
+
+	invalid := &TLSInfo{Error: "handshake failed"}
+	if colorizeTLS(invalid) != colorRed+"invalid"+colorReset {
+		t.Errorf("Color for invalid TLSInfo is incorrect")
+	}
+
+	soon := &TLSInfo{NotAfter: time.Now().Add(3 * 24 * time.Hour), ChainValid: true, SANMatch: true}
+	if colorizeTLS(soon) != colorRed+"3d"+colorReset {
+		t.Errorf("Color for soon-expiring cert is incorrect, got %q", colorizeTLS(soon))
+	}
+
+	healthy := &TLSInfo{NotAfter: time.Now().Add(90 * 24 * time.Hour), ChainValid: true, SANMatch: true}
+	if colorizeTLS(healthy) != colorGreen+"90d"+colorReset {
+		t.Errorf("Color for healthy cert is incorrect, got %q", colorizeTLS(healthy))
+	}
+}
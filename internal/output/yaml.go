@@ -0,0 +1,15 @@
+package output
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlOutputter struct{}
+
+func (yamlOutputter) Write(w io.Writer, results []Result) error {
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(results)
+}
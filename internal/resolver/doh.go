@@ -0,0 +1,97 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/miekg/dns"
+)
+
+const dohContentType = "application/dns-message"
+
+// dohResolver queries a resolver over DNS-over-HTTPS (RFC 8484) using
+// the POST form of the wire format.
+type dohResolver struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newDoHResolver(endpoint string) *dohResolver {
+	return &dohResolver{endpoint: endpoint, client: &http.Client{}}
+}
+
+func (r *dohResolver) exchange(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	msg.RecursionDesired = true
+
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver: %s answered HTTP %d", r.endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return nil, err
+	}
+
+	if reply.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("resolver: %s answered rcode %s for %s", r.endpoint, dns.RcodeToString[reply.Rcode], name)
+	}
+
+	return reply, nil
+}
+
+func (r *dohResolver) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeA)
+	if err != nil {
+		return nil, err
+	}
+	return aRecords(resp), nil
+}
+
+func (r *dohResolver) LookupCNAME(ctx context.Context, name string) (string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeCNAME)
+	if err != nil {
+		return "", err
+	}
+	return cnameRecord(resp, name), nil
+}
+
+func (r *dohResolver) LookupMX(ctx context.Context, name string) ([]string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeMX)
+	if err != nil {
+		return nil, err
+	}
+	return mxRecords(resp), nil
+}
+
+func (r *dohResolver) String() string {
+	return r.endpoint
+}
@@ -0,0 +1,64 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+// dotResolver queries a resolver over DNS-over-TLS (RFC 7858).
+type dotResolver struct {
+	addr string
+}
+
+func newDoTResolver(addr string) *dotResolver {
+	return &dotResolver{addr: addr}
+}
+
+func (r *dotResolver) exchange(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	msg.RecursionDesired = true
+
+	client := &dns.Client{Net: "tcp-tls"}
+	resp, _, err := client.ExchangeContext(ctx, msg, r.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("resolver: %s answered rcode %s for %s", r.addr, dns.RcodeToString[resp.Rcode], name)
+	}
+
+	return resp, nil
+}
+
+func (r *dotResolver) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeA)
+	if err != nil {
+		return nil, err
+	}
+	return aRecords(resp), nil
+}
+
+func (r *dotResolver) LookupCNAME(ctx context.Context, name string) (string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeCNAME)
+	if err != nil {
+		return "", err
+	}
+	return cnameRecord(resp, name), nil
+}
+
+func (r *dotResolver) LookupMX(ctx context.Context, name string) ([]string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeMX)
+	if err != nil {
+		return nil, err
+	}
+	return mxRecords(resp), nil
+}
+
+func (r *dotResolver) String() string {
+	return "tls://" + r.addr
+}
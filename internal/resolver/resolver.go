@@ -0,0 +1,59 @@
+// Package resolver lets doch ask a specific DNS resolver — a plain
+// UDP/TCP server, a DNS-over-TLS host, or a DNS-over-HTTPS endpoint —
+// rather than always going through whatever the OS has configured.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Resolver answers the handful of record types doch cares about.
+type Resolver interface {
+	// LookupA returns the IPv4 addresses for name.
+	LookupA(ctx context.Context, name string) ([]net.IP, error)
+	// LookupCNAME returns the canonical name for name, or name itself
+	// (with a trailing dot) if there is no CNAME.
+	LookupCNAME(ctx context.Context, name string) (string, error)
+	// LookupMX returns MX hostnames for name, ordered by preference.
+	LookupMX(ctx context.Context, name string) ([]string, error)
+	// String identifies the resolver for logging, e.g. "udp://1.1.1.1:53".
+	String() string
+}
+
+// Parse turns a --resolver flag value into a Resolver. Supported
+// schemes:
+//
+//	udp://host:port    plain UDP, falling back to TCP on truncation
+//	tls://host:port    DNS-over-TLS (RFC 7858)
+//	https://host/path  DNS-over-HTTPS (RFC 8484)
+func Parse(spec string) (Resolver, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: invalid spec %q: %w", spec, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "udp":
+		return newUDPResolver(withDefaultPort(u.Host, "53")), nil
+	case "tls", "dot":
+		return newDoTResolver(withDefaultPort(u.Host, "853")), nil
+	case "https":
+		return newDoHResolver(spec), nil
+	case "doh":
+		u.Scheme = "https"
+		return newDoHResolver(u.String()), nil
+	default:
+		return nil, fmt.Errorf("resolver: unsupported scheme %q in %q", u.Scheme, spec)
+	}
+}
+
+func withDefaultPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
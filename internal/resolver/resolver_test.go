@@ -0,0 +1,36 @@
+package resolver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"udp://1.1.1.1", "udp://1.1.1.1:53", false},
+		{"udp://1.1.1.1:5353", "udp://1.1.1.1:5353", false},
+		{"tls://1.1.1.1", "tls://1.1.1.1:853", false},
+		{"dot://1.1.1.1", "tls://1.1.1.1:853", false},
+		{"https://dns.google/dns-query", "https://dns.google/dns-query", false},
+		{"doh://dns.google/dns-query", "https://dns.google/dns-query", false},
+		{"ftp://1.1.1.1", "", true},
+	}
+
+	for _, tt := range tests {
+		r, err := Parse(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q): expected an error, got none", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", tt.spec, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
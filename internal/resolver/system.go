@@ -0,0 +1,34 @@
+package resolver
+
+import (
+	"context"
+	"net"
+)
+
+// System delegates to the host's configured resolver. It's the default
+// when no --resolver flags are given.
+type System struct{}
+
+func (System) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	return net.DefaultResolver.LookupIP(ctx, "ip4", name)
+}
+
+func (System) LookupCNAME(ctx context.Context, name string) (string, error) {
+	return net.DefaultResolver.LookupCNAME(ctx, name)
+}
+
+func (System) LookupMX(ctx context.Context, name string) ([]string, error) {
+	records, err := net.DefaultResolver.LookupMX(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(records))
+	for _, mx := range records {
+		hosts = append(hosts, mx.Host)
+	}
+	return hosts, nil
+}
+
+func (System) String() string {
+	return "system"
+}
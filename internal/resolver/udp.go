@@ -0,0 +1,118 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+// udpResolver queries a plain UDP resolver, retrying over TCP when the
+// UDP reply is truncated.
+type udpResolver struct {
+	addr string
+}
+
+func newUDPResolver(addr string) *udpResolver {
+	return &udpResolver{addr: addr}
+}
+
+func (r *udpResolver) exchange(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	msg.RecursionDesired = true
+
+	client := &dns.Client{Net: "udp"}
+	resp, _, err := client.ExchangeContext(ctx, msg, r.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.ExchangeContext(ctx, msg, r.addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("resolver: %s answered rcode %s for %s", r.addr, dns.RcodeToString[resp.Rcode], name)
+	}
+
+	return resp, nil
+}
+
+func (r *udpResolver) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeA)
+	if err != nil {
+		return nil, err
+	}
+	return aRecords(resp), nil
+}
+
+func (r *udpResolver) LookupCNAME(ctx context.Context, name string) (string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeCNAME)
+	if err != nil {
+		return "", err
+	}
+	return cnameRecord(resp, name), nil
+}
+
+func (r *udpResolver) LookupMX(ctx context.Context, name string) ([]string, error) {
+	resp, err := r.exchange(ctx, name, dns.TypeMX)
+	if err != nil {
+		return nil, err
+	}
+	return mxRecords(resp), nil
+}
+
+func (r *udpResolver) String() string {
+	return "udp://" + r.addr
+}
+
+func aRecords(resp *dns.Msg) []net.IP {
+	var ips []net.IP
+	for _, rr := range resp.Answer {
+		if a, ok := rr.(*dns.A); ok {
+			ips = append(ips, a.A)
+		}
+	}
+	return ips
+}
+
+func cnameRecord(resp *dns.Msg, fallback string) string {
+	for _, rr := range resp.Answer {
+		if cname, ok := rr.(*dns.CNAME); ok {
+			return cname.Target
+		}
+	}
+	return dns.Fqdn(fallback)
+}
+
+func mxRecords(resp *dns.Msg) []string {
+	type pref struct {
+		host string
+		p    uint16
+	}
+	var mxs []pref
+	for _, rr := range resp.Answer {
+		if mx, ok := rr.(*dns.MX); ok {
+			mxs = append(mxs, pref{host: mx.Mx, p: mx.Preference})
+		}
+	}
+
+	// Answers aren't guaranteed to arrive preference-sorted.
+	for i := 1; i < len(mxs); i++ {
+		for j := i; j > 0 && mxs[j].p < mxs[j-1].p; j-- {
+			mxs[j], mxs[j-1] = mxs[j-1], mxs[j]
+		}
+	}
+
+	hosts := make([]string, 0, len(mxs))
+	for _, mx := range mxs {
+		hosts = append(hosts, mx.host)
+	}
+	return hosts
+}